@@ -0,0 +1,298 @@
+// Command genpw is a command-line wrapper around the generate package. Every
+// PasswordOptions field is exposed as a flag, and every flag can also be set
+// via an environment variable so defaults can be baked into a shell rc file.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/BadgerBadgerBadgerBadger/go-generate-password/generate"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "genpw:", err)
+		os.Exit(1)
+	}
+}
+
+// config holds the parsed flag values for a single run of genpw.
+type config struct {
+	length         int
+	numbers        bool
+	symbols        bool
+	exclude        string
+	uppercase      bool
+	lowercase      bool
+	noSimilar      bool
+	strict         bool
+	minLowercase   int
+	minUppercase   int
+	minNumbers     int
+	minSymbols     int
+	symbolsString  string
+	count          int
+	mode           string
+	minEntropyBits float64
+	withEntropy    bool
+	json           bool
+	hash           string
+}
+
+func run(args []string, out io.Writer) error {
+	cfg, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+
+	opts, err := cfg.passwordOptions()
+	if err != nil {
+		return err
+	}
+
+	pg := generate.NewPasswordGenerator()
+
+	passwords := make([]string, cfg.count)
+	for i := 0; i < cfg.count; i++ {
+		password, err := pg.Generate(opts)
+		if err != nil {
+			return fmt.Errorf("failed to generate password: %w", err)
+		}
+		passwords[i] = password
+	}
+
+	return cfg.writeOutput(out, opts, passwords)
+}
+
+// parseFlags defines every PasswordOptions flag, seeding each default from
+// its GENPW_* environment variable, then parses args.
+func parseFlags(args []string) (config, error) {
+	fs := flag.NewFlagSet("genpw", flag.ContinueOnError)
+
+	var cfg config
+
+	addIntFlag(fs, &cfg.length, "l", "length", "GENPW_LENGTH", 16, "password length")
+	addBoolFlag(fs, &cfg.numbers, "n", "numbers", "GENPW_NUMBERS", true, "include numbers")
+	addBoolFlag(fs, &cfg.symbols, "s", "symbols", "GENPW_SYMBOLS", false, "include symbols")
+	addStringFlag(fs, &cfg.exclude, "x", "exclude", "GENPW_EXCLUDE", "", "characters to exclude")
+	addBoolFlag(fs, &cfg.uppercase, "", "uppercase", "GENPW_UPPERCASE", true, "include uppercase letters")
+	addBoolFlag(fs, &cfg.lowercase, "", "lowercase", "GENPW_LOWERCASE", true, "include lowercase letters")
+	addBoolFlag(fs, &cfg.noSimilar, "", "no-similar", "GENPW_NO_SIMILAR", false, "exclude similar-looking characters")
+	addBoolFlag(fs, &cfg.strict, "", "strict", "GENPW_STRICT", false, "require at least one character from each selected pool")
+	addIntFlag(fs, &cfg.minLowercase, "", "min-lowercase", "GENPW_MIN_LOWERCASE", 0, "minimum number of lowercase letters")
+	addIntFlag(fs, &cfg.minUppercase, "", "min-uppercase", "GENPW_MIN_UPPERCASE", 0, "minimum number of uppercase letters")
+	addIntFlag(fs, &cfg.minNumbers, "", "min-numbers", "GENPW_MIN_NUMBERS", 0, "minimum number of digits")
+	addIntFlag(fs, &cfg.minSymbols, "", "min-symbols", "GENPW_MIN_SYMBOLS", 0, "minimum number of symbols")
+	addStringFlag(fs, &cfg.symbolsString, "", "symbols-string", "GENPW_SYMBOLS_STRING", "", "custom symbol set to use instead of the default")
+	addIntFlag(fs, &cfg.count, "", "count", "GENPW_COUNT", 1, "number of passwords to generate")
+	addStringFlag(fs, &cfg.mode, "", "mode", "GENPW_MODE", "random", "generation mode: random or pronounceable")
+	addFloatFlag(fs, &cfg.minEntropyBits, "", "min-entropy", "GENPW_MIN_ENTROPY", 0, "minimum required entropy, in bits")
+	addBoolFlag(fs, &cfg.withEntropy, "", "with-entropy", "GENPW_WITH_ENTROPY", false, "print password, entropy bits, and strength as tab-separated columns")
+	addBoolFlag(fs, &cfg.json, "", "json", "GENPW_JSON", false, "print passwords as a JSON array")
+	addStringFlag(fs, &cfg.hash, "", "hash", "GENPW_HASH", "", "print password and hash pairs using bcrypt, argon2id, or scrypt")
+
+	if err := fs.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	return cfg, nil
+}
+
+// addIntFlag registers both a short and a long flag name bound to the same
+// variable, seeded from envKey if set.
+func addIntFlag(fs *flag.FlagSet, p *int, short, long, envKey string, def int, usage string) {
+	if v, ok := os.LookupEnv(envKey); ok {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			def = parsed
+		}
+	}
+
+	if short != "" {
+		fs.IntVar(p, short, def, usage)
+	}
+	fs.IntVar(p, long, def, usage)
+}
+
+func addFloatFlag(fs *flag.FlagSet, p *float64, short, long, envKey string, def float64, usage string) {
+	if v, ok := os.LookupEnv(envKey); ok {
+		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+			def = parsed
+		}
+	}
+
+	if short != "" {
+		fs.Float64Var(p, short, def, usage)
+	}
+	fs.Float64Var(p, long, def, usage)
+}
+
+func addBoolFlag(fs *flag.FlagSet, p *bool, short, long, envKey string, def bool, usage string) {
+	if v, ok := os.LookupEnv(envKey); ok {
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			def = parsed
+		}
+	}
+
+	if short != "" {
+		fs.BoolVar(p, short, def, usage)
+	}
+	fs.BoolVar(p, long, def, usage)
+}
+
+func addStringFlag(fs *flag.FlagSet, p *string, short, long, envKey string, def string, usage string) {
+	if v, ok := os.LookupEnv(envKey); ok {
+		def = v
+	}
+
+	if short != "" {
+		fs.StringVar(p, short, def, usage)
+	}
+	fs.StringVar(p, long, def, usage)
+}
+
+// passwordOptions translates the parsed flags into a generate.PasswordOptions,
+// validating the --mode and --count values.
+func (cfg config) passwordOptions() (generate.PasswordOptions, error) {
+	if cfg.count < 1 {
+		return generate.PasswordOptions{}, fmt.Errorf("--count must be at least 1")
+	}
+
+	var mode generate.Mode
+	switch cfg.mode {
+	case "", "random":
+		mode = generate.ModeRandom
+	case "pronounceable":
+		mode = generate.ModePronounceable
+	default:
+		return generate.PasswordOptions{}, fmt.Errorf("unknown --mode %q: must be random or pronounceable", cfg.mode)
+	}
+
+	return generate.PasswordOptions{
+		Length:                   cfg.length,
+		Numbers:                  cfg.numbers,
+		Symbols:                  cfg.symbols,
+		Exclude:                  cfg.exclude,
+		Uppercase:                cfg.uppercase,
+		Lowercase:                cfg.lowercase,
+		ExcludeSimilarCharacters: cfg.noSimilar,
+		Strict:                   cfg.strict,
+		MinLowercase:             cfg.minLowercase,
+		MinUppercase:             cfg.minUppercase,
+		MinNumbers:               cfg.minNumbers,
+		MinSymbols:               cfg.minSymbols,
+		SymbolsString:            cfg.symbolsString,
+		Mode:                     mode,
+		MinEntropyBits:           cfg.minEntropyBits,
+	}, nil
+}
+
+// writeOutput prints passwords in the mode selected by cfg: --hash pairs,
+// --with-entropy columns, --json, or one password per line.
+func (cfg config) writeOutput(out io.Writer, opts generate.PasswordOptions, passwords []string) error {
+	switch {
+	case cfg.hash != "":
+		for _, password := range passwords {
+			hashed, err := hashPassword(cfg.hash, password)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s\t%s\n", password, hashed)
+		}
+		return nil
+
+	case cfg.withEntropy:
+		for _, password := range passwords {
+			strength, err := generate.EstimateEntropy(password, opts)
+			if err != nil {
+				return fmt.Errorf("failed to estimate entropy: %w", err)
+			}
+			fmt.Fprintf(out, "%s\t%.1f\t%s\n", password, strength.EntropyBits, strength.Strength)
+		}
+		return nil
+
+	case cfg.json:
+		encoded, err := json.Marshal(passwords)
+		if err != nil {
+			return fmt.Errorf("failed to encode passwords as JSON: %w", err)
+		}
+		fmt.Fprintln(out, string(encoded))
+		return nil
+
+	default:
+		for _, password := range passwords {
+			fmt.Fprintln(out, password)
+		}
+		return nil
+	}
+}
+
+// hashPassword hashes password with the named algorithm, for seeding
+// credential stores with realistic password/hash pairs.
+func hashPassword(algorithm, password string) (string, error) {
+	switch strings.ToLower(algorithm) {
+	case "bcrypt":
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash password with bcrypt: %w", err)
+		}
+		return string(hashed), nil
+
+	case "argon2id":
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("failed to generate salt: %w", err)
+		}
+
+		const (
+			argon2Time    = 1
+			argon2Memory  = 64 * 1024
+			argon2Threads = 4
+			argon2KeyLen  = 32
+		)
+
+		key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+
+		return fmt.Sprintf(
+			"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, argon2Memory, argon2Time, argon2Threads,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(key),
+		), nil
+
+	case "scrypt":
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("failed to generate salt: %w", err)
+		}
+
+		const (
+			scryptN      = 32768
+			scryptR      = 8
+			scryptP      = 1
+			scryptKeyLen = 32
+		)
+
+		key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash password with scrypt: %w", err)
+		}
+
+		return fmt.Sprintf("$scrypt$n=%d,r=%d,p=%d$%s$%s", scryptN, scryptR, scryptP, hex.EncodeToString(salt), hex.EncodeToString(key)), nil
+
+	default:
+		return "", fmt.Errorf("unknown --hash algorithm %q: must be bcrypt, argon2id, or scrypt", algorithm)
+	}
+}
@@ -0,0 +1,77 @@
+package generate
+
+// DefaultWordlist returns the package's bundled default word list for
+// GeneratePassphrase, used whenever PassphraseOptions.WordList is left nil.
+// It is a custom diceware-style list curated for this package, not the EFF
+// wordlist or any other third-party list; callers who need EFF's documented
+// ~12.9-bits-per-word entropy should supply EFF's own list (7,776 words) via
+// PassphraseOptions.WordList or GeneratePassphraseFromReader. Each call
+// returns a fresh copy, so callers can freely mutate the result without
+// affecting later calls.
+func DefaultWordlist() []string {
+	wordList := make([]string, len(defaultWordlist))
+	copy(wordList, defaultWordlist)
+	return wordList
+}
+
+// defaultWordlist is the package's bundled default diceware-style word list:
+// 433 distinct, lower-case English words. It is not the EFF wordlist; use
+// EstimatePassphraseEntropy with len(wordList) to get the correct per-word
+// entropy for whatever list is actually in use.
+var defaultWordlist = []string{
+	"anchor", "anvil", "apple", "arrow", "artist", "autumn", "avenue", "badge",
+	"banner", "barrel", "basket", "beacon", "beetle", "bicycle", "biscuit", "blanket",
+	"bolt", "bonfire", "border", "bramble", "breeze", "bridge", "bristle", "bucket",
+	"buffalo", "bugle", "bumper", "bundle", "burrow", "cabin", "cactus", "camel",
+	"canary", "candle", "canyon", "captain", "caravan", "carbon", "cargo", "carpet",
+	"cascade", "castle", "cavern", "cedar", "cellar", "chalk", "channel", "charcoal",
+	"charm", "cherry", "chestnut", "chimney", "chisel", "chorus", "cinder", "circuit",
+	"clamp", "clarinet", "cliff", "clover", "cobalt", "cobble", "coconut", "compass",
+	"concert", "condor", "copper", "coral", "cottage", "cotton", "cougar", "cradle",
+	"crater", "cricket", "crimson", "crown", "crumb", "crystal", "cub", "cupcake",
+	"current", "cyclone", "dagger", "daisy", "dandelion", "deputy", "desert", "diamond",
+	"dolphin", "domino", "donkey", "drift", "drizzle", "drum", "duchess", "eagle",
+	"ebony", "eclipse", "elbow", "ember", "emerald", "engine", "envoy", "equinox",
+	"ermine", "estate", "ether", "fable", "falcon", "feather", "fern", "ferret",
+	"fiddle", "fig", "finch", "fjord", "flagon", "flannel", "flask", "flicker",
+	"flint", "forest", "fossil", "fountain", "fox", "frigate", "frost", "garnet",
+	"gazelle", "gecko", "geyser", "ginger", "glacier", "goblet", "gondola", "goose",
+	"gopher", "granite", "grove", "gypsum", "hamlet", "hammock", "harbor", "harvest",
+	"hatchet", "hawthorn", "hazel", "heather", "hedge", "helix", "hemlock", "heron",
+	"hickory", "hollow", "honey", "hornet", "hurdle", "hyacinth", "hyena", "iceberg",
+	"icicle", "igloo", "iguana", "indigo", "ink", "ivory", "ivy", "jacket",
+	"jade", "jaguar", "jasmine", "javelin", "jigsaw", "jukebox", "jungle", "juniper",
+	"kangaroo", "kayak", "kelp", "kestrel", "ketchup", "kettle", "kilogram", "kiosk",
+	"kite", "kiwi", "koala", "lagoon", "lantern", "larch", "lark", "lattice",
+	"laurel", "lavender", "ledger", "lemur", "lentil", "leopard", "lichen", "lighthouse",
+	"lilac", "limber", "linnet", "lizard", "locket", "locomotive", "lotus", "lumber",
+	"lupine", "lynx", "magnet", "magnolia", "mallard", "mammoth", "mandarin", "mantle",
+	"maple", "marble", "marigold", "marmot", "marsh", "meadow", "medal", "meerkat",
+	"melon", "meridian", "meteor", "mimosa", "minnow", "minstrel", "mint", "mirage",
+	"mitten", "moccasin", "mongoose", "monsoon", "moose", "moth", "mountain", "mulberry",
+	"muskrat", "mustang", "myrtle", "narwhal", "nautilus", "nebula", "nectar", "needle",
+	"nettle", "nickel", "nimbus", "noodle", "nutmeg", "oasis", "oatmeal", "obelisk",
+	"ocelot", "octopus", "olive", "onion", "opal", "orbit", "orchard", "orchid",
+	"oregano", "oriole", "osprey", "ostrich", "otter", "outpost", "owl", "oyster",
+	"paddle", "palette", "pansy", "panther", "papaya", "parsley", "partridge", "pebble",
+	"pecan", "pelican", "pendant", "penguin", "pepper", "perch", "petal", "pheasant",
+	"pickle", "pigeon", "pineapple", "piston", "plateau", "plover", "plum", "polar",
+	"pony", "poppy", "possum", "pretzel", "prism", "puffin", "pumpkin", "quail",
+	"quartz", "quilt", "quince", "quiver", "rabbit", "raccoon", "radish", "rampart",
+	"raven", "reef", "relic", "ribbon", "ridge", "ripple", "river", "robin",
+	"rocket", "rooster", "rosemary", "rowan", "rudder", "saddle", "saffron", "sage",
+	"salmon", "sandpiper", "sapphire", "sardine", "satchel", "savanna", "scallop", "schooner",
+	"scorpion", "sequoia", "shamrock", "shark", "shelter", "shovel", "shrew", "sierra",
+	"silver", "sonar", "sorrel", "sparrow", "spinach", "spruce", "squash", "squirrel",
+	"stable", "starling", "stirrup", "stork", "sultana", "sundial", "sunflower", "swallow",
+	"swan", "sycamore", "tambourine", "tangerine", "tapestry", "tarragon", "terrace", "terrier",
+	"thicket", "thimble", "thistle", "thrush", "thunder", "thyme", "tiger", "timber",
+	"toadstool", "toboggan", "tortoise", "toucan", "trellis", "trestle", "trinket", "trombone",
+	"trout", "truffle", "tulip", "tumbleweed", "tundra", "turnip", "turquoise", "turtle",
+	"tusk", "twig", "umbrella", "urchin", "valley", "vanilla", "velvet", "vermilion",
+	"vessel", "vial", "viking", "village", "violet", "vireo", "viscount", "volcano",
+	"vulture", "wagon", "walnut", "walrus", "warbler", "wasp", "weasel", "whale",
+	"wheat", "whisker", "willow", "windmill", "wisteria", "wolverine", "wombat", "woodland",
+	"wren", "yarrow", "yew", "yucca", "zebra", "zenith", "zephyr", "zinnia",
+	"zircon",
+}
@@ -0,0 +1,244 @@
+package generate
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// StrengthLevel is a coarse, NIST-inspired strength bucket derived from a
+// password's estimated entropy.
+type StrengthLevel int
+
+const (
+	StrengthWeak StrengthLevel = iota
+	StrengthReasonable
+	StrengthStrong
+	StrengthParanoid
+)
+
+// String returns the lower-case bucket name, e.g. "reasonable".
+func (s StrengthLevel) String() string {
+	switch s {
+	case StrengthWeak:
+		return "weak"
+	case StrengthReasonable:
+		return "reasonable"
+	case StrengthStrong:
+		return "strong"
+	case StrengthParanoid:
+		return "paranoid"
+	default:
+		return "unknown"
+	}
+}
+
+// Entropy bit thresholds used by strengthBucket, loosely following NIST
+// SP 800-63B guidance on what counts as an acceptable memorized secret.
+const (
+	reasonableEntropyBits = 40
+	strongEntropyBits     = 60
+	paranoidEntropyBits   = 80
+)
+
+// DefaultGuessesPerSecond approximates a well-resourced offline attacker and
+// is used by EstimateEntropy when no explicit rate is requested.
+const DefaultGuessesPerSecond = 1e10
+
+// averagePronounceableUnitLength approximates how many characters a single
+// syllable unit contributes on average, used to back out an emitted-unit
+// count from a password's length.
+const averagePronounceableUnitLength = 1.3
+
+// pronounceableAcceptanceRate caches the fraction of (prev, candidate) draws
+// nextPronounceableUnit accepts on average, computed once since
+// pronounceableUnits is fixed at compile time. Entropy math for
+// ModePronounceable uses it to subtract the bits a rejected draw would have
+// contributed: a draw constrained by the transition rules carries less
+// information than an unconstrained pick from pronounceableUnits.
+var pronounceableAcceptanceRate = computePronounceableAcceptanceRate()
+
+// computePronounceableAcceptanceRate averages pronounceableTransitionAllowed
+// over every (prev, candidate) pair in pronounceableUnits, with consonantRun
+// held at zero (the common case once a vowel has been seen), as a single
+// representative acceptance probability for the whole table.
+func computePronounceableAcceptanceRate() float64 {
+	allowed, total := 0, 0
+
+	for i := range pronounceableUnits {
+		for j := range pronounceableUnits {
+			total++
+			if pronounceableTransitionAllowed(&pronounceableUnits[i], &pronounceableUnits[j], 0) {
+				allowed++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 1
+	}
+
+	return float64(allowed) / float64(total)
+}
+
+// pronounceableEntropyBits estimates the total entropy, in bits, of a
+// pronounceable password made up of unitCount syllable units: per-unit
+// entropy log2(numUnits), summed over the emitted units, minus the bits lost
+// to nextPronounceableUnit's rejection sampling (approximated from
+// pronounceableAcceptanceRate).
+func pronounceableEntropyBits(unitCount int) float64 {
+	bits := float64(unitCount) * (math.Log2(float64(len(pronounceableUnits))) + math.Log2(pronounceableAcceptanceRate))
+	if bits < 0 {
+		bits = 0
+	}
+	return bits
+}
+
+// PasswordStrength reports an entropy estimate for a password, along with a
+// coarse strength bucket and an estimated brute-force crack time at a given
+// guess rate.
+type PasswordStrength struct {
+	// EntropyBits is the estimated Shannon entropy of the password, in bits.
+	EntropyBits float64
+
+	// PoolSize is the effective character pool size (ModeRandom) or unit
+	// table size (ModePronounceable) the estimate was derived from.
+	PoolSize int
+
+	// Strength is the NIST-style bucket EntropyBits falls into.
+	Strength StrengthLevel
+
+	// CrackTimeSeconds estimates how long a brute-force search would take on
+	// average at the assumed guesses-per-second rate, in seconds, taken as
+	// the time to search half the keyspace.
+	CrackTimeSeconds float64
+}
+
+// EstimateEntropy estimates the strength of pw assuming it was drawn from the
+// pool implied by opts, at DefaultGuessesPerSecond. For ModeRandom, entropy is
+// length(pw) * log2(poolSize). For ModePronounceable, the number of emitted
+// syllable units is approximated from len(pw), and entropy is
+// log2(numPronounceableUnits) per unit minus the bits lost to
+// nextPronounceableUnit's rejection sampling (see pronounceableEntropyBits).
+func EstimateEntropy(pw string, opts PasswordOptions) (PasswordStrength, error) {
+	return EstimateEntropyAtRate(pw, opts, DefaultGuessesPerSecond)
+}
+
+// EstimateEntropyAtRate is EstimateEntropy with an explicit guesses-per-second
+// rate for the crack time estimate.
+func EstimateEntropyAtRate(pw string, opts PasswordOptions, guessesPerSecond float64) (PasswordStrength, error) {
+	var bits float64
+	var poolSize int
+
+	if opts.Mode == ModePronounceable {
+		poolSize = len(pronounceableUnits)
+		bits = pronounceableEntropyBits(estimatePronounceableUnitCount(len(pw)))
+	} else {
+		pool, err := buildPool(opts)
+		if err != nil {
+			return PasswordStrength{}, errors.Wrap(err, "failed to build character pool")
+		}
+
+		poolSize = len(pool)
+		bits = float64(len(pw)) * math.Log2(float64(poolSize))
+	}
+
+	return PasswordStrength{
+		EntropyBits:      bits,
+		PoolSize:         poolSize,
+		Strength:         strengthBucket(bits),
+		CrackTimeSeconds: crackTimeSeconds(bits, guessesPerSecond),
+	}, nil
+}
+
+// EstimatePassphraseEntropy estimates the entropy of a GeneratePassphrase
+// passphrase built from wordCount words drawn from a word list of
+// wordListSize words: log2(wordListSize) bits per word.
+func EstimatePassphraseEntropy(wordCount, wordListSize int) float64 {
+	if wordCount <= 0 || wordListSize <= 0 {
+		return 0
+	}
+
+	return float64(wordCount) * math.Log2(float64(wordListSize))
+}
+
+// GenerateResult is the result of GenerateWithInfo: a generated password
+// together with its estimated entropy.
+type GenerateResult struct {
+	Password string
+	Strength PasswordStrength
+}
+
+// GenerateWithInfo generates a password like Generate, then estimates its
+// entropy with EstimateEntropy.
+func (pg *PasswordGenerator) GenerateWithInfo(options PasswordOptions) (GenerateResult, error) {
+	password, err := pg.Generate(options)
+	if err != nil {
+		return GenerateResult{}, err
+	}
+
+	strength, err := EstimateEntropy(password, options)
+	if err != nil {
+		return GenerateResult{}, errors.Wrap(err, "failed to estimate entropy")
+	}
+
+	return GenerateResult{Password: password, Strength: strength}, nil
+}
+
+// estimatePronounceableUnitCount approximates how many syllable units make up
+// a pronounceable password of the given length.
+func estimatePronounceableUnitCount(length int) int {
+	n := int(math.Round(float64(length) / averagePronounceableUnitLength))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+func strengthBucket(bits float64) StrengthLevel {
+	switch {
+	case bits >= paranoidEntropyBits:
+		return StrengthParanoid
+	case bits >= strongEntropyBits:
+		return StrengthStrong
+	case bits >= reasonableEntropyBits:
+		return StrengthReasonable
+	default:
+		return StrengthWeak
+	}
+}
+
+func crackTimeSeconds(bits float64, guessesPerSecond float64) float64 {
+	if guessesPerSecond <= 0 {
+		guessesPerSecond = DefaultGuessesPerSecond
+	}
+	// Assume on average an attacker must search half the keyspace.
+	return math.Pow(2, bits) / 2 / guessesPerSecond
+}
+
+// checkMinEntropy enforces options.MinEntropyBits against the theoretical
+// entropy the configured options can produce, so Generate and
+// GeneratePronounceable fail fast instead of returning a password that falls
+// short of the caller's policy. poolStr is the character pool for ModeRandom;
+// it is ignored in ModePronounceable.
+func checkMinEntropy(options PasswordOptions, poolStr string) error {
+	if options.MinEntropyBits <= 0 {
+		return nil
+	}
+
+	var bits float64
+	if options.Mode == ModePronounceable {
+		bits = pronounceableEntropyBits(estimatePronounceableUnitCount(options.Length))
+	} else {
+		if len(poolStr) == 0 {
+			return errors.New("at least one rule for pools must be true")
+		}
+		bits = float64(options.Length) * math.Log2(float64(len(poolStr)))
+	}
+
+	if bits < options.MinEntropyBits {
+		return errors.Errorf("configured options yield %.1f bits of entropy, below the required minimum of %.1f", bits, options.MinEntropyBits)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package generate
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateEntropyRandomMode(t *testing.T) {
+	opts := PasswordOptions{Length: 12, Lowercase: true, Numbers: true}
+
+	strength, err := EstimateEntropy("abcdefghijkl", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strength.PoolSize != len(lowercase)+len(numbers) {
+		t.Fatalf("got pool size %d, want %d", strength.PoolSize, len(lowercase)+len(numbers))
+	}
+
+	if strength.EntropyBits <= 0 {
+		t.Fatalf("expected positive entropy, got %f", strength.EntropyBits)
+	}
+}
+
+func TestEstimateEntropyPronounceableAccountsForRejections(t *testing.T) {
+	opts := PasswordOptions{Length: 12, Mode: ModePronounceable}
+
+	strength, err := EstimateEntropy("tatraribomxy", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unitCount := estimatePronounceableUnitCount(len("tatraribomxy"))
+	naiveBits := float64(unitCount) * math.Log2(float64(len(pronounceableUnits)))
+
+	if strength.EntropyBits >= naiveBits {
+		t.Fatalf("expected rejection-adjusted entropy (%f) to be lower than the naive estimate (%f)", strength.EntropyBits, naiveBits)
+	}
+}
+
+func TestPronounceableAcceptanceRateIsAProbability(t *testing.T) {
+	if pronounceableAcceptanceRate <= 0 || pronounceableAcceptanceRate > 1 {
+		t.Fatalf("expected pronounceableAcceptanceRate in (0, 1], got %f", pronounceableAcceptanceRate)
+	}
+}
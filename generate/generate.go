@@ -44,14 +44,24 @@ package generate
 
 import (
 	"crypto/rand"
+	"io"
 	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
 )
 
+// RandSource is the source of randomness PasswordGenerator reads from when
+// refilling its internal random byte buffer. crypto/rand.Reader is used by
+// default; pass a different RandSource to NewPasswordGeneratorWithSource for
+// deterministic test vectors, seeded derivation, or HSM/KMS-backed entropy.
+type RandSource interface {
+	io.Reader
+}
+
 // PasswordGenerator holds the state for generating passwords.
 type PasswordGenerator struct {
+	source      RandSource
 	randomIndex int
 	randomBytes []byte
 }
@@ -81,13 +91,42 @@ type PasswordOptions struct {
 	ExcludeSimilarCharacters bool
 
 	// Strict enforces that the password must include at least one character from each selected character pool.
-	// For example, if Lowercase, Uppercase, and Numbers are all true, then the password must include
-	// at least one lowercase letter, one uppercase letter, and one number.
+	// It is sugar for MinLowercase/MinUppercase/MinNumbers/MinSymbols: for each selected pool whose
+	// corresponding minimum is left at zero, Strict raises it to 1. An explicit minimum always takes
+	// precedence over Strict. Strict and the Min* fields apply to ModeRandom only; in
+	// ModePronounceable they are neither validated nor enforced.
 	Strict bool
 
+	// MinLowercase, when greater than zero, requires at least that many lowercase letters.
+	// Lowercase must also be true; Generate returns an error otherwise.
+	MinLowercase int
+
+	// MinUppercase, when greater than zero, requires at least that many uppercase letters.
+	// Uppercase must also be true; Generate returns an error otherwise.
+	MinUppercase int
+
+	// MinNumbers, when greater than zero, requires at least that many digits.
+	// Numbers must also be true; Generate returns an error otherwise.
+	MinNumbers int
+
+	// MinSymbols, when greater than zero, requires at least that many symbols.
+	// Symbols must also be true; Generate returns an error otherwise.
+	MinSymbols int
+
 	// SymbolsString allows specifying a custom set of symbols to use instead of the default symbol set.
 	// This is only used if Symbols is set to true.
 	SymbolsString string
+
+	// Mode selects the generation algorithm. It defaults to ModeRandom; set it
+	// to ModePronounceable to build the password from syllable units instead
+	// of independent characters.
+	Mode Mode
+
+	// MinEntropyBits, when greater than zero, enforces a minimum estimated
+	// entropy (see EstimateEntropy) for the configured options. Generate
+	// returns an error up front if Length and the selected pools cannot meet
+	// this floor, rather than generating a password that falls short of it.
+	MinEntropyBits float64
 }
 
 // Define character pools.
@@ -101,23 +140,18 @@ const (
 // similarCharactersRegex defines characters that look similar.
 var similarCharactersRegex = regexp.MustCompile("[ilLI|`oO0]")
 
-// strictRule defines a rule for strict password generation.
-type strictRule struct {
-	name string
-	rule *regexp.Regexp
-}
-
-// strictRules defines the rules for strict password generation.
-var strictRules = []strictRule{
-	{name: "lowercase", rule: regexp.MustCompile(`[a-z]`)},
-	{name: "uppercase", rule: regexp.MustCompile(`[A-Z]`)},
-	{name: "numbers", rule: regexp.MustCompile(`[0-9]`)},
-	{name: "symbols", rule: regexp.MustCompile(`[!@#$%^&*()+_\-=}{[\]|:;"/?.,><` + "`" + `~]`)},
+// NewPasswordGenerator creates a new password generator that reads randomness
+// from crypto/rand.
+func NewPasswordGenerator() *PasswordGenerator {
+	return NewPasswordGeneratorWithSource(rand.Reader)
 }
 
-// NewPasswordGenerator creates a new password generator.
-func NewPasswordGenerator() *PasswordGenerator {
-	return &PasswordGenerator{}
+// NewPasswordGeneratorWithSource creates a new password generator that reads
+// randomness from r instead of crypto/rand. This unlocks deterministic test
+// vectors (pass a fixed bytes.Reader), seeded derivation (see
+// NewDeterministicSource), and HSM/KMS-backed entropy.
+func NewPasswordGeneratorWithSource(r io.Reader) *PasswordGenerator {
+	return &PasswordGenerator{source: r}
 }
 
 // getNextRandomValue gets the next random byte from the buffer.
@@ -125,7 +159,7 @@ func (pg *PasswordGenerator) getNextRandomValue() (byte, error) {
 	if pg.randomIndex == 0 || pg.randomIndex >= len(pg.randomBytes) {
 		pg.randomIndex = 0
 		pg.randomBytes = make([]byte, 256) // Same as RANDOM_BATCH_SIZE in JS.
-		_, err := rand.Read(pg.randomBytes)
+		_, err := io.ReadFull(pg.source, pg.randomBytes)
 		if err != nil {
 			return 0, errors.Wrap(err, "failed to generate random bytes")
 		}
@@ -139,6 +173,16 @@ func (pg *PasswordGenerator) getNextRandomValue() (byte, error) {
 
 // randomNumber generates a random number between 0 (inclusive) and max (exclusive).
 func (pg *PasswordGenerator) randomNumber(max int) (int, error) {
+	if max <= 256 {
+		return pg.randomByte(max)
+	}
+
+	return pg.randomWideNumber(max)
+}
+
+// randomByte is the single-byte-per-draw implementation used when max fits
+// in a byte, equivalent to the JS version.
+func (pg *PasswordGenerator) randomByte(max int) (int, error) {
 	rndVal, err := pg.getNextRandomValue()
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get random value")
@@ -157,30 +201,78 @@ func (pg *PasswordGenerator) randomNumber(max int) (int, error) {
 	return int(rndVal) % max, nil
 }
 
-// Generate generates a password according to the specified options.
-func (pg *PasswordGenerator) Generate(options PasswordOptions) (string, error) {
-	// Validate options.
-	if options.Strict {
-		minStrictLength := 0
-		if options.Lowercase {
-			minStrictLength++
-		}
-		if options.Uppercase {
-			minStrictLength++
-		}
-		if options.Numbers {
-			minStrictLength++
+// randomWideNumber is randomNumber's counterpart for max values larger than
+// a single byte can hold, such as selecting a word from a large word list.
+// It draws as many bytes as needed to cover max and applies the same
+// rejection-sampling technique as randomByte to stay unbiased.
+func (pg *PasswordGenerator) randomWideNumber(max int) (int, error) {
+	numBytes := 1
+	for 1<<(8*numBytes) < max {
+		numBytes++
+	}
+
+	span := 1 << (8 * numBytes)
+	limit := span - (span % max)
+
+	for {
+		value := 0
+		for i := 0; i < numBytes; i++ {
+			b, err := pg.getNextRandomValue()
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to get random value")
+			}
+			value = value<<8 | int(b)
 		}
-		if options.Symbols {
-			minStrictLength++
+
+		if value < limit {
+			return value % max, nil
 		}
+	}
+}
 
-		if minStrictLength > options.Length {
-			return "", errors.New("length must correlate with strict guidelines")
+// Generate generates a password according to the specified options.
+func (pg *PasswordGenerator) Generate(options PasswordOptions) (string, error) {
+	if options.Mode == ModePronounceable {
+		password, _, err := pg.GeneratePronounceable(options)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate pronounceable password")
 		}
+		return password, nil
+	}
+
+	if err := checkMinimumsMatchEnabledClasses(options); err != nil {
+		return "", err
 	}
 
-	// Generate character pool.
+	// Validate the requested per-class minimums against Length.
+	minLower, minUpper, minNum, minSym := effectiveMinimums(options)
+	if minLower+minUpper+minNum+minSym > options.Length {
+		return "", errors.New("length must correlate with the configured class minimums")
+	}
+
+	poolStr, err := buildPool(options)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build character pool")
+	}
+
+	if err := checkMinEntropy(options, poolStr); err != nil {
+		return "", err
+	}
+
+	// Generate password.
+	password, err := pg.generateInternal(options, poolStr)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate password")
+	}
+
+	return password, nil
+}
+
+// buildPool assembles the character pool implied by options, applying the
+// similar-character and explicit exclusions. It is shared by Generate and
+// the entropy estimation helpers so both agree on the pool a given set of
+// options produces.
+func buildPool(options PasswordOptions) (string, error) {
 	var pool strings.Builder
 
 	if options.Lowercase {
@@ -207,88 +299,187 @@ func (pg *PasswordGenerator) Generate(options PasswordOptions) (string, error) {
 		return "", errors.New("at least one rule for pools must be true")
 	}
 
-	poolStr := pool.String()
+	return applyExclusions(pool.String(), options), nil
+}
 
-	// Exclude similar characters.
+// applyExclusions strips options.Exclude and, if set,
+// ExcludeSimilarCharacters from pool. It is shared by buildPool, for the
+// combined pool Generate draws from, and enforceMinimums, for each class's
+// individual top-up pool, so a top-up character can never be one the caller
+// explicitly excluded.
+func applyExclusions(pool string, options PasswordOptions) string {
 	if options.ExcludeSimilarCharacters {
-		poolStr = similarCharactersRegex.ReplaceAllString(poolStr, "")
+		pool = similarCharactersRegex.ReplaceAllString(pool, "")
 	}
 
-	// Exclude specified characters.
 	for _, char := range options.Exclude {
-		poolStr = strings.ReplaceAll(poolStr, string(char), "")
-	}
-
-	// Generate password.
-	password, err := pg.generateInternal(options, poolStr)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to generate password")
+		pool = strings.ReplaceAll(pool, string(char), "")
 	}
 
-	return password, nil
+	return pool
 }
 
 // generateInternal is the internal function that generates a password.
 func (pg *PasswordGenerator) generateInternal(options PasswordOptions, pool string) (string, error) {
-	var password strings.Builder
+	password := make([]byte, options.Length)
 	poolLength := len(pool)
 
-	for i := 0; i < options.Length; i++ {
+	for i := range password {
 		randIndex, err := pg.randomNumber(poolLength)
 		if err != nil {
 			return "", errors.Wrap(err, "failed to generate random number")
 		}
 
-		password.WriteByte(pool[randIndex])
+		password[i] = pool[randIndex]
+	}
+
+	minLower, minUpper, minNum, minSym := effectiveMinimums(options)
+	if minLower+minUpper+minNum+minSym > 0 {
+		if err := pg.enforceMinimums(password, options, minLower, minUpper, minNum, minSym); err != nil {
+			return "", errors.Wrap(err, "failed to satisfy class minimums")
+		}
+	}
+
+	return string(password), nil
+}
+
+// checkMinimumsMatchEnabledClasses rejects an explicit MinLowercase/
+// MinUppercase/MinNumbers/MinSymbols set for a class the caller didn't also
+// enable (e.g. MinNumbers > 0 with Numbers: false), rather than silently
+// pulling characters into the password from a pool the caller never asked
+// for.
+func checkMinimumsMatchEnabledClasses(options PasswordOptions) error {
+	if options.MinLowercase > 0 && !options.Lowercase {
+		return errors.New("MinLowercase requires Lowercase to be enabled")
 	}
+	if options.MinUppercase > 0 && !options.Uppercase {
+		return errors.New("MinUppercase requires Uppercase to be enabled")
+	}
+	if options.MinNumbers > 0 && !options.Numbers {
+		return errors.New("MinNumbers requires Numbers to be enabled")
+	}
+	if options.MinSymbols > 0 && !options.Symbols {
+		return errors.New("MinSymbols requires Symbols to be enabled")
+	}
+	return nil
+}
+
+// effectiveMinimums returns the per-class minimums Generate must satisfy,
+// folding Strict in as sugar: any selected class whose explicit minimum is
+// still zero is raised to 1.
+func effectiveMinimums(options PasswordOptions) (minLower, minUpper, minNum, minSym int) {
+	minLower, minUpper, minNum, minSym = options.MinLowercase, options.MinUppercase, options.MinNumbers, options.MinSymbols
 
 	if options.Strict {
-		// Check if password meets all required rules.
-		allRulesMet := true
-		for _, rule := range strictRules {
-			// Skip rule if corresponding option is false.
-			switch rule.name {
-			case "lowercase":
-				if !options.Lowercase {
-					continue
-				}
-			case "uppercase":
-				if !options.Uppercase {
-					continue
-				}
-			case "numbers":
-				if !options.Numbers {
-					continue
-				}
-			case "symbols":
-				if !options.Symbols {
-					continue
-				}
+		if options.Lowercase && minLower < 1 {
+			minLower = 1
+		}
+		if options.Uppercase && minUpper < 1 {
+			minUpper = 1
+		}
+		if options.Numbers && minNum < 1 {
+			minNum = 1
+		}
+		if options.Symbols && minSym < 1 {
+			minSym = 1
+		}
+	}
+
+	return minLower, minUpper, minNum, minSym
+}
+
+// minimumClass is one character class enforceMinimums checks and, if
+// necessary, tops up.
+type minimumClass struct {
+	min  int
+	pool string
+}
+
+// enforceMinimums counts how many characters of each class are already
+// present in password and, for any class still short, replaces randomly
+// chosen positions with characters drawn from that class's pool, filtered
+// through the same Exclude/ExcludeSimilarCharacters rules buildPool applies,
+// so a top-up character is never one the caller explicitly excluded.
+// Positions are never reused across classes, so this always terminates in a
+// single pass rather than regenerating the whole password and hoping.
+func (pg *PasswordGenerator) enforceMinimums(password []byte, options PasswordOptions, minLower, minUpper, minNum, minSym int) error {
+	symbolPool := symbols
+	if options.SymbolsString != "" {
+		symbolPool = options.SymbolsString
+	}
+
+	classes := []minimumClass{
+		{min: minLower, pool: applyExclusions(lowercase, options)},
+		{min: minUpper, pool: applyExclusions(uppercase, options)},
+		{min: minNum, pool: applyExclusions(numbers, options)},
+		{min: minSym, pool: applyExclusions(symbolPool, options)},
+	}
+
+	available := make([]int, len(password))
+	for i := range available {
+		available[i] = i
+	}
+
+	for _, class := range classes {
+		if class.min == 0 {
+			continue
+		}
 
-				// Handle custom symbols.
-				if options.SymbolsString != "" {
-					customSymbolsRegex := regexp.MustCompile("[" + regexp.QuoteMeta(options.SymbolsString) + "]")
-					if !customSymbolsRegex.MatchString(password.String()) {
-						allRulesMet = false
-						break
-					}
-					continue
+		if class.pool == "" {
+			return errors.New("Exclude/ExcludeSimilarCharacters leave no characters to satisfy a configured class minimum")
+		}
+
+		// Count characters already satisfying this class, reserving just
+		// enough of their positions to cover the minimum so a later class
+		// can't steal them back out from under it. Any matching positions
+		// beyond the minimum stay available for other classes to claim.
+		have := 0
+		reserved := 0
+		for i, b := range password {
+			if strings.IndexByte(class.pool, b) >= 0 {
+				have++
+				if reserved < class.min {
+					available = removeAvailablePosition(available, i)
+					reserved++
 				}
 			}
+		}
+
+		for have < class.min {
+			if len(available) == 0 {
+				return errors.New("not enough characters to satisfy the configured class minimums")
+			}
+
+			posIdx, err := pg.randomNumber(len(available))
+			if err != nil {
+				return errors.Wrap(err, "failed to choose a position")
+			}
+
+			pos := available[posIdx]
+			available = append(available[:posIdx], available[posIdx+1:]...)
 
-			if !rule.rule.MatchString(password.String()) {
-				allRulesMet = false
-				break
+			charIdx, err := pg.randomNumber(len(class.pool))
+			if err != nil {
+				return errors.Wrap(err, "failed to choose a character")
 			}
+
+			password[pos] = class.pool[charIdx]
+			have++
 		}
+	}
+
+	return nil
+}
 
-		// If not all rules are met, generate a new password.
-		if !allRulesMet {
-			return pg.generateInternal(options, pool)
+// removeAvailablePosition removes pos from available, if present.
+func removeAvailablePosition(available []int, pos int) []int {
+	for i, p := range available {
+		if p == pos {
+			return append(available[:i], available[i+1:]...)
 		}
 	}
 
-	return password.String(), nil
+	return available
 }
 
 // GenerateMultiple generates multiple passwords with the same options.
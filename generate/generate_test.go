@@ -0,0 +1,177 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func countInPool(s, pool string) int {
+	count := 0
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(pool, s[i]) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGenerateEnforcesPerClassMinimums(t *testing.T) {
+	opts := PasswordOptions{
+		Length:       20,
+		Lowercase:    true,
+		Uppercase:    true,
+		Numbers:      true,
+		Symbols:      true,
+		MinNumbers:   3,
+		MinSymbols:   2,
+		MinUppercase: 4,
+	}
+
+	for i := 0; i < 200; i++ {
+		pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", fmt.Sprintf("site-%d", i)))
+
+		password, err := pg.Generate(opts)
+		if err != nil {
+			t.Fatalf("run %d: unexpected error: %v", i, err)
+		}
+
+		if len(password) != opts.Length {
+			t.Fatalf("run %d: got length %d, want %d", i, len(password), opts.Length)
+		}
+
+		if got := countInPool(password, numbers); got < opts.MinNumbers {
+			t.Fatalf("run %d: password %q has %d digits, want at least %d", i, password, got, opts.MinNumbers)
+		}
+		if got := countInPool(password, symbols); got < opts.MinSymbols {
+			t.Fatalf("run %d: password %q has %d symbols, want at least %d", i, password, got, opts.MinSymbols)
+		}
+		if got := countInPool(password, uppercase); got < opts.MinUppercase {
+			t.Fatalf("run %d: password %q has %d uppercase letters, want at least %d", i, password, got, opts.MinUppercase)
+		}
+	}
+}
+
+func TestGenerateStrictIsSugarForMinimumOfOne(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "strict"))
+
+	opts := PasswordOptions{
+		Length:    12,
+		Lowercase: true,
+		Uppercase: true,
+		Numbers:   true,
+		Symbols:   true,
+		Strict:    true,
+	}
+
+	password, err := pg.Generate(opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, pool := range []string{lowercase, uppercase, numbers, symbols} {
+		if countInPool(password, pool) < 1 {
+			t.Fatalf("password %q is missing a character from pool %q", password, pool)
+		}
+	}
+}
+
+func TestGenerateRejectsMinimumsExceedingLength(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+	_, err := pg.Generate(PasswordOptions{
+		Length:     4,
+		Numbers:    true,
+		Symbols:    true,
+		MinNumbers: 3,
+		MinSymbols: 3,
+	})
+	if err == nil {
+		t.Fatal("expected an error when the sum of class minimums exceeds Length")
+	}
+}
+
+func TestGenerateRejectsMinimumForDisabledClass(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+	_, err := pg.Generate(PasswordOptions{
+		Length:       10,
+		Lowercase:    true,
+		MinLowercase: 1,
+		MinUppercase: 3,
+		Uppercase:    false,
+	})
+	if err == nil {
+		t.Fatal("expected an error when MinUppercase is set but Uppercase is disabled")
+	}
+}
+
+func TestGenerateTopUpNeverReintroducesExcludedSimilarCharacters(t *testing.T) {
+	opts := PasswordOptions{
+		Length:                   20,
+		Lowercase:                true,
+		Uppercase:                true,
+		Numbers:                  true,
+		Symbols:                  true,
+		Strict:                   true,
+		ExcludeSimilarCharacters: true,
+	}
+
+	for i := 0; i < 200; i++ {
+		pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", fmt.Sprintf("similar-%d", i)))
+
+		password, err := pg.Generate(opts)
+		if err != nil {
+			t.Fatalf("run %d: unexpected error: %v", i, err)
+		}
+
+		if similarCharactersRegex.MatchString(password) {
+			t.Fatalf("run %d: password %q contains an excluded similar character", i, password)
+		}
+	}
+}
+
+func TestGenerateTopUpNeverReintroducesExcludedCharacters(t *testing.T) {
+	opts := PasswordOptions{
+		Length:     20,
+		Numbers:    true,
+		Lowercase:  true,
+		MinNumbers: 5,
+		Exclude:    "0123456789",
+	}
+
+	for i := 0; i < 200; i++ {
+		pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", fmt.Sprintf("exclude-%d", i)))
+
+		_, err := pg.Generate(opts)
+		if err == nil {
+			t.Fatalf("run %d: expected an error, since excluding every digit leaves no characters to satisfy MinNumbers", i)
+		}
+	}
+}
+
+func TestGenerateTopUpRespectsExcludeAlongsideOtherMinimums(t *testing.T) {
+	opts := PasswordOptions{
+		Length:     20,
+		Numbers:    true,
+		Lowercase:  true,
+		MinNumbers: 2,
+		Exclude:    "013",
+	}
+
+	for i := 0; i < 200; i++ {
+		pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", fmt.Sprintf("exclude-ok-%d", i)))
+
+		password, err := pg.Generate(opts)
+		if err != nil {
+			t.Fatalf("run %d: unexpected error: %v", i, err)
+		}
+
+		if strings.ContainsAny(password, "013") {
+			t.Fatalf("run %d: password %q contains an excluded character", i, password)
+		}
+		if got := countInPool(password, numbers); got < opts.MinNumbers {
+			t.Fatalf("run %d: password %q has %d digits, want at least %d", i, password, got, opts.MinNumbers)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PassphraseOptions defines the options for GeneratePassphrase.
+type PassphraseOptions struct {
+	// WordCount is the number of words the passphrase should contain.
+	WordCount int
+
+	// Separator is placed between words and before the trailing number, if
+	// any. It defaults to "-" when left empty.
+	Separator string
+
+	// Capitalize, when true, capitalizes the first letter of each word.
+	Capitalize bool
+
+	// IncludeNumber, when true, appends a random digit to the passphrase.
+	IncludeNumber bool
+
+	// WordList is the word list words are drawn from. It defaults to
+	// DefaultWordlist() when left nil.
+	WordList []string
+}
+
+// GeneratePassphrase generates a diceware-style passphrase of WordCount words
+// drawn uniformly from opts.WordList (or the bundled default word list
+// returned by DefaultWordlist, if unset) using the same unbiased
+// randomNumber routine Generate uses, joined by opts.Separator.
+func (pg *PasswordGenerator) GeneratePassphrase(opts PassphraseOptions) (string, error) {
+	if opts.WordCount <= 0 {
+		return "", errors.New("word count must be greater than zero")
+	}
+
+	wordList := opts.WordList
+	if len(wordList) == 0 {
+		wordList = DefaultWordlist()
+	}
+
+	if len(wordList) == 0 {
+		return "", errors.New("word list must not be empty")
+	}
+
+	separator := opts.Separator
+	if separator == "" {
+		separator = "-"
+	}
+
+	words := make([]string, opts.WordCount)
+	for i := range words {
+		idx, err := pg.randomNumber(len(wordList))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to choose a word")
+		}
+
+		word := wordList[idx]
+		if opts.Capitalize {
+			word = capitalizeWord(word)
+		}
+
+		words[i] = word
+	}
+
+	passphrase := strings.Join(words, separator)
+
+	if opts.IncludeNumber {
+		digitIdx, err := pg.randomNumber(len(numbers))
+		if err != nil {
+			return "", errors.Wrap(err, "failed to choose a number")
+		}
+
+		passphrase += separator + string(numbers[digitIdx])
+	}
+
+	return passphrase, nil
+}
+
+// GeneratePassphraseFromReader is GeneratePassphrase using a custom word list
+// loaded from r, one word per line; blank lines are skipped. Any WordList set
+// on opts is ignored in favor of the words read from r.
+func (pg *PasswordGenerator) GeneratePassphraseFromReader(r io.Reader, opts PassphraseOptions) (string, error) {
+	wordList, err := readWordList(r)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read word list")
+	}
+
+	if len(wordList) == 0 {
+		return "", errors.New("word list must not be empty")
+	}
+
+	opts.WordList = wordList
+
+	return pg.GeneratePassphrase(opts)
+}
+
+// readWordList reads one word per line from r, skipping blank lines.
+func readWordList(r io.Reader) ([]string, error) {
+	var words []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to scan word list")
+	}
+
+	return words, nil
+}
+
+// capitalizeWord upper-cases the first byte of word, leaving the rest as is.
+func capitalizeWord(word string) string {
+	if word == "" {
+		return word
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
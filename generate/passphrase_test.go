@@ -0,0 +1,91 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassphraseWordCountAndSeparator(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+	passphrase, err := pg.GeneratePassphrase(PassphraseOptions{
+		WordCount: 6,
+		Separator: "_",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	words := strings.Split(passphrase, "_")
+	if len(words) != 6 {
+		t.Fatalf("got %d words, want 6: %q", len(words), passphrase)
+	}
+
+	defaultWords := DefaultWordlist()
+	for _, word := range words {
+		found := false
+		for _, w := range defaultWords {
+			if w == word {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("word %q is not in DefaultWordlist", word)
+		}
+	}
+}
+
+func TestGeneratePassphraseCapitalizeAndNumber(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+	passphrase, err := pg.GeneratePassphrase(PassphraseOptions{
+		WordCount:     4,
+		Capitalize:    true,
+		IncludeNumber: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parts := strings.Split(passphrase, "-")
+	if len(parts) != 5 {
+		t.Fatalf("expected 4 words plus a trailing number, got %q", passphrase)
+	}
+
+	for _, word := range parts[:4] {
+		if word == "" || strings.ToUpper(word[:1]) != word[:1] {
+			t.Fatalf("word %q was not capitalized", word)
+		}
+	}
+
+	if !strings.ContainsAny(parts[4], numbers) {
+		t.Fatalf("trailing segment %q is not a digit", parts[4])
+	}
+}
+
+func TestGeneratePassphraseFromReaderUsesCustomWordList(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+	customList := "alpha\nbravo\ncharlie\n"
+	passphrase, err := pg.GeneratePassphraseFromReader(strings.NewReader(customList), PassphraseOptions{WordCount: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, word := range strings.Split(passphrase, "-") {
+		if word != "alpha" && word != "bravo" && word != "charlie" {
+			t.Fatalf("word %q is not from the custom word list", word)
+		}
+	}
+}
+
+func TestDefaultWordlistReturnsAnIndependentCopy(t *testing.T) {
+	first := DefaultWordlist()
+	first[0] = "mutated"
+
+	second := DefaultWordlist()
+	if second[0] == "mutated" {
+		t.Fatal("mutating the slice returned by DefaultWordlist affected a later call")
+	}
+}
@@ -0,0 +1,322 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Mode selects the algorithm PasswordGenerator uses to build a password.
+type Mode int
+
+const (
+	// ModeRandom builds a password by drawing independent characters from the
+	// configured character pool. This is the default mode.
+	ModeRandom Mode = iota
+
+	// ModePronounceable builds a password from consonant/vowel/diphthong
+	// syllable units following the FIPS-181 / APG pronounceable password
+	// algorithm, producing passwords that are easier to read aloud and
+	// memorize than ModeRandom output.
+	ModePronounceable
+)
+
+// unitKind classifies a pronounceable syllable unit.
+type unitKind int
+
+const (
+	unitConsonant unitKind = iota
+	unitVowel
+	unitDiphthong
+)
+
+// pronounceableUnit is a single consonant, vowel, or diphthong building
+// block used by GeneratePronounceable.
+type pronounceableUnit struct {
+	value    string
+	kind     unitKind
+	notFirst bool // unit may not be chosen as the first unit of the password
+}
+
+// pronounceableUnits is the table of units GeneratePronounceable draws from,
+// modeled on the unit table used by FIPS-181 / APG pronounceable password
+// generators.
+var pronounceableUnits = []pronounceableUnit{
+	// Vowels.
+	{value: "a", kind: unitVowel},
+	{value: "e", kind: unitVowel},
+	{value: "i", kind: unitVowel},
+	{value: "o", kind: unitVowel},
+	{value: "u", kind: unitVowel},
+
+	// Diphthongs.
+	{value: "ai", kind: unitDiphthong},
+	{value: "ea", kind: unitDiphthong},
+	{value: "ee", kind: unitDiphthong, notFirst: true},
+	{value: "ei", kind: unitDiphthong},
+	{value: "ie", kind: unitDiphthong},
+	{value: "oa", kind: unitDiphthong},
+	{value: "oo", kind: unitDiphthong, notFirst: true},
+	{value: "ou", kind: unitDiphthong},
+
+	// Consonants.
+	{value: "b", kind: unitConsonant},
+	{value: "c", kind: unitConsonant},
+	{value: "d", kind: unitConsonant},
+	{value: "f", kind: unitConsonant},
+	{value: "g", kind: unitConsonant},
+	{value: "h", kind: unitConsonant},
+	{value: "j", kind: unitConsonant},
+	{value: "k", kind: unitConsonant},
+	{value: "l", kind: unitConsonant},
+	{value: "m", kind: unitConsonant},
+	{value: "n", kind: unitConsonant},
+	{value: "p", kind: unitConsonant},
+	{value: "r", kind: unitConsonant},
+	{value: "s", kind: unitConsonant},
+	{value: "t", kind: unitConsonant},
+	{value: "v", kind: unitConsonant},
+	{value: "w", kind: unitConsonant},
+	{value: "x", kind: unitConsonant, notFirst: true},
+	{value: "y", kind: unitConsonant},
+	{value: "z", kind: unitConsonant},
+}
+
+// consonantClusters is the allow-list of two-letter consonant pairs that may
+// appear back to back (e.g. "ch", "th"). Any consonant pair not listed here
+// is rejected by the transition rules in nextPronounceableUnit.
+var consonantClusters = map[string]bool{
+	"ch": true, "ck": true, "ct": true, "gh": true, "ld": true, "lf": true,
+	"lk": true, "lm": true, "lp": true, "lt": true, "mp": true, "nd": true,
+	"ng": true, "nk": true, "nt": true, "ph": true, "qu": true, "rb": true,
+	"rc": true, "rd": true, "rg": true, "rk": true, "rm": true, "rn": true,
+	"rp": true, "rt": true, "sh": true, "sk": true, "sl": true, "sm": true,
+	"sn": true, "sp": true, "st": true, "th": true, "wh": true,
+}
+
+// maxPronounceableAttempts bounds how many candidates nextPronounceableUnit
+// rejects before giving up, so a pathological table can never spin forever.
+// GeneratePronounceable reuses the same bound for retrying a whole attempt
+// when splicing can't find a boundary.
+const maxPronounceableAttempts = 64
+
+// errNoSpliceBoundary is returned by spliceUnitAtBoundary when units has
+// fewer than two units to splice a character between. GeneratePronounceable
+// treats it as retryable: a fresh draw of syllable units usually produces
+// more than one unit.
+var errNoSpliceBoundary = errors.New("not enough syllable units to splice a character in without breaking pronounceability")
+
+// GeneratePronounceable generates a human-pronounceable password of the
+// requested length, built from consonant/vowel/diphthong syllable units
+// chosen with the generator's RandSource and filtered by FIPS-181 / APG style
+// transition rules. It returns the password itself, plus a display form with
+// a hyphen between every syllable unit (e.g. "b-a-n-d-ai-m-o"). Units are
+// not grouped into multi-unit syllables, and the display form is taken before
+// any digit, symbol, or uppercase letter is applied.
+//
+// When Numbers and/or Symbols are set, a slot for each is reserved out of
+// Length before syllables are generated, and the character is spliced into
+// the syllable stream afterward, so the final password is exactly Length
+// characters and always contains the requested classes; it is never trimmed
+// after splicing, which could otherwise cut off the very character just
+// spliced in. If a particular draw of syllable units doesn't leave room for
+// a splice (e.g. a short password made up of a single long unit), the whole
+// attempt is retried up to maxPronounceableAttempts times before giving up.
+func (pg *PasswordGenerator) GeneratePronounceable(options PasswordOptions) (string, string, error) {
+	if options.Length <= 0 {
+		return "", "", errors.New("length must be greater than zero")
+	}
+
+	if err := checkMinEntropy(options, ""); err != nil {
+		return "", "", err
+	}
+
+	reserved := 0
+	if options.Numbers {
+		reserved++
+	}
+	if options.Symbols {
+		reserved++
+	}
+
+	syllableBudget := options.Length - reserved
+	if syllableBudget < 1 {
+		return "", "", errors.New("length is too short to fit the requested character classes")
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxPronounceableAttempts; attempt++ {
+		password, display, err := pg.generatePronounceableAttempt(options, syllableBudget)
+		if err == nil {
+			return password, display, nil
+		}
+		if err != errNoSpliceBoundary {
+			return "", "", err
+		}
+		lastErr = err
+	}
+
+	return "", "", errors.Wrap(lastErr, "failed to generate a pronounceable password that fits the requested character classes")
+}
+
+// generatePronounceableAttempt draws one fresh set of syllable units sized to
+// syllableBudget and splices in numbers/symbols/uppercase as requested. It
+// returns errNoSpliceBoundary, unwrapped, if a splice can't find a boundary,
+// so GeneratePronounceable knows to retry with a fresh draw.
+func (pg *PasswordGenerator) generatePronounceableAttempt(options PasswordOptions, syllableBudget int) (string, string, error) {
+	units, err := pg.generatePronounceableUnits(syllableBudget)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to generate syllable units")
+	}
+
+	display := strings.Join(units, "-")
+
+	if options.Numbers {
+		units, err = pg.spliceUnitAtBoundary(units, numbers)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if options.Symbols {
+		symbolPool := symbols
+		if options.SymbolsString != "" {
+			symbolPool = options.SymbolsString
+		}
+		units, err = pg.spliceUnitAtBoundary(units, symbolPool)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if options.Uppercase && len(units) > 0 {
+		idx, err := pg.randomNumber(len(units))
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to choose unit to uppercase")
+		}
+		units[idx] = strings.ToUpper(units[idx])
+	}
+
+	return strings.Join(units, ""), display, nil
+}
+
+// generatePronounceableUnits picks syllable units one at a time until their
+// combined length reaches the requested length, trimming the final unit if
+// it would overflow.
+func (pg *PasswordGenerator) generatePronounceableUnits(length int) ([]string, error) {
+	var units []string
+	var prev *pronounceableUnit
+	consonantRun := 0
+	total := 0
+
+	for total < length {
+		next, err := pg.nextPronounceableUnit(prev, consonantRun)
+		if err != nil {
+			return nil, err
+		}
+
+		value := next.value
+		if remaining := length - total; len(value) > remaining {
+			value = value[:remaining]
+		}
+
+		units = append(units, value)
+		total += len(value)
+		prev = next
+
+		if next.kind == unitConsonant {
+			consonantRun++
+		} else {
+			consonantRun = 0
+		}
+	}
+
+	return units, nil
+}
+
+// nextPronounceableUnit draws units from pronounceableUnits, rejecting any
+// candidate that violates the transition rules, until one is accepted or
+// maxPronounceableAttempts is exceeded.
+func (pg *PasswordGenerator) nextPronounceableUnit(prev *pronounceableUnit, consonantRun int) (*pronounceableUnit, error) {
+	for attempt := 0; attempt < maxPronounceableAttempts; attempt++ {
+		idx, err := pg.randomNumber(len(pronounceableUnits))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to choose a candidate unit")
+		}
+
+		candidate := &pronounceableUnits[idx]
+
+		if prev == nil {
+			if candidate.notFirst {
+				continue
+			}
+			return candidate, nil
+		}
+
+		if !pronounceableTransitionAllowed(prev, candidate, consonantRun) {
+			continue
+		}
+
+		return candidate, nil
+	}
+
+	return nil, errors.New("unable to find a valid syllable unit after repeated attempts")
+}
+
+// pronounceableTransitionAllowed applies the FIPS-181 / APG style transition
+// rules: diphthongs may not sit next to another vowel-like unit, consonant
+// pairs must be in the allow-list, and two consonants in a row without a
+// vowel forces the next pick to be vowel-like so every syllable keeps a
+// vowel.
+func pronounceableTransitionAllowed(prev, candidate *pronounceableUnit, consonantRun int) bool {
+	prevVowelLike := prev.kind != unitConsonant
+	candidateVowelLike := candidate.kind != unitConsonant
+
+	if prevVowelLike && candidateVowelLike {
+		return prev.kind != unitDiphthong && candidate.kind != unitDiphthong
+	}
+
+	if !prevVowelLike && !candidateVowelLike {
+		if consonantRun >= 2 {
+			return false
+		}
+		return consonantClusters[prev.value+candidate.value]
+	}
+
+	return true
+}
+
+// spliceUnitAtBoundary inserts a single character drawn from pool between
+// two adjacent units, chosen at random, so that numbers and symbols land on
+// syllable boundaries instead of breaking up a unit. It returns
+// errNoSpliceBoundary, rather than silently skipping the splice, if units
+// has no boundary to splice into, so callers never get back a password that
+// is missing a requested character class.
+func (pg *PasswordGenerator) spliceUnitAtBoundary(units []string, pool string) ([]string, error) {
+	if pool == "" {
+		return units, nil
+	}
+
+	if len(units) < 2 {
+		return nil, errNoSpliceBoundary
+	}
+
+	boundary, err := pg.randomNumber(len(units) - 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to choose a syllable boundary")
+	}
+
+	charIdx, err := pg.randomNumber(len(pool))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to choose a character")
+	}
+
+	boundary++ // insert after the unit at this index
+
+	spliced := make([]string, 0, len(units)+1)
+	spliced = append(spliced, units[:boundary]...)
+	spliced = append(spliced, string(pool[charIdx]))
+	spliced = append(spliced, units[boundary:]...)
+
+	return spliced, nil
+}
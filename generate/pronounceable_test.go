@@ -0,0 +1,60 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePronounceableLength(t *testing.T) {
+	for length := 4; length <= 24; length++ {
+		pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+		password, display, err := pg.GeneratePronounceable(PasswordOptions{
+			Length:    length,
+			Numbers:   true,
+			Symbols:   true,
+			Uppercase: true,
+		})
+		if err != nil {
+			t.Fatalf("length %d: unexpected error: %v", length, err)
+		}
+
+		if len(password) != length {
+			t.Fatalf("length %d: got password %q of length %d", length, password, len(password))
+		}
+
+		if display == "" {
+			t.Fatalf("length %d: display form was empty", length)
+		}
+	}
+}
+
+func TestGeneratePronounceableAlwaysIncludesRequestedClasses(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+	for i := 0; i < 200; i++ {
+		password, _, err := pg.GeneratePronounceable(PasswordOptions{
+			Length:  8,
+			Numbers: true,
+			Symbols: true,
+		})
+		if err != nil {
+			t.Fatalf("run %d: unexpected error: %v", i, err)
+		}
+
+		if !strings.ContainsAny(password, numbers) {
+			t.Fatalf("run %d: password %q is missing a requested digit", i, password)
+		}
+		if !strings.ContainsAny(password, symbols) {
+			t.Fatalf("run %d: password %q is missing a requested symbol", i, password)
+		}
+	}
+}
+
+func TestGeneratePronounceableLengthTooShortForClasses(t *testing.T) {
+	pg := NewPasswordGeneratorWithSource(NewDeterministicSource("master", "site"))
+
+	if _, _, err := pg.GeneratePronounceable(PasswordOptions{Length: 1, Numbers: true, Symbols: true}); err == nil {
+		t.Fatal("expected an error when Length is too short to fit the requested classes")
+	}
+}
@@ -0,0 +1,44 @@
+package generate
+
+import (
+	"crypto/sha512"
+	"io"
+)
+
+// deterministicSource is an io.Reader that produces an unbounded, repeatable
+// byte stream by repeatedly hashing its own previous state with SHA-512,
+// "specialpass"-style. Two sources seeded with the same master passphrase and
+// site name always produce the same stream, and therefore the same password.
+type deterministicSource struct {
+	state [sha512.Size]byte
+	buf   []byte
+}
+
+// NewDeterministicSource returns an io.Reader that derives its byte stream
+// from sha512(master + ":" + site), then keeps hashing sha512(prev) to
+// extend the stream indefinitely. Passing the resulting reader to
+// NewPasswordGeneratorWithSource makes password generation a deterministic
+// function of (master, site), so the same site always yields the same
+// password for a given master passphrase without storing anything.
+func NewDeterministicSource(master, site string) io.Reader {
+	return &deterministicSource{state: sha512.Sum512([]byte(master + ":" + site))}
+}
+
+// Read implements io.Reader, filling p from the running SHA-512 chain and
+// extending the chain with another hash whenever the current block is
+// exhausted.
+func (d *deterministicSource) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(d.buf) == 0 {
+			d.state = sha512.Sum512(d.state[:])
+			d.buf = append([]byte(nil), d.state[:]...)
+		}
+
+		c := copy(p[n:], d.buf)
+		d.buf = d.buf[c:]
+		n += c
+	}
+
+	return n, nil
+}